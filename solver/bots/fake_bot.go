@@ -1,17 +1,9 @@
 package main
 
-import (
-    "io"
-    "log"
-    "net/http"
-)
+import "log"
 
 func main() {
-    h := func(w http.ResponseWriter, _ *http.Request) {
-        io.WriteString(w, "Hello from a HandleFunc!\n")
+    if err := run(); err != nil {
+        log.Fatal(err)
     }
-
-    http.HandleFunc("/", h)
-    log.Println("Listening on http://localhost:8080")
-    log.Fatal(http.ListenAndServe(":8080", nil))
 }
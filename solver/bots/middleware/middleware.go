@@ -0,0 +1,114 @@
+// Package middleware provides composable http.Handler wrappers for
+// cross-cutting concerns like access logging and response compression.
+package middleware
+
+import (
+    "bufio"
+    "compress/gzip"
+    "log"
+    "net"
+    "net/http"
+    "strings"
+    "time"
+)
+
+// Chain applies handlers in order, so that Chain(A, B)(h) behaves like
+// A(B(h)): A runs first on the way in and last on the way out.
+func Chain(handlers ...func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+    return func(h http.Handler) http.Handler {
+        for i := len(handlers) - 1; i >= 0; i-- {
+            h = handlers[i](h)
+        }
+        return h
+    }
+}
+
+// Logger emits an Apache-style access line for every request.
+func Logger(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        start := time.Now()
+        rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+        next.ServeHTTP(rw, r)
+
+        log.Printf("%s \"%s %s %s\" %d %d %q %q %s",
+            r.RemoteAddr, r.Method, r.URL.RequestURI(), r.Proto,
+            rw.status, rw.bytes, r.Referer(), r.UserAgent(), time.Since(start))
+    })
+}
+
+// responseWriter wraps an http.ResponseWriter to capture the status code
+// and byte count written, so Logger can report them after the handler runs.
+type responseWriter struct {
+    http.ResponseWriter
+    status int
+    bytes  int
+}
+
+func (rw *responseWriter) WriteHeader(status int) {
+    rw.status = status
+    rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+    n, err := rw.ResponseWriter.Write(b)
+    rw.bytes += n
+    return n, err
+}
+
+func (rw *responseWriter) Flush() {
+    if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+        f.Flush()
+    }
+}
+
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+    h, ok := rw.ResponseWriter.(http.Hijacker)
+    if !ok {
+        return nil, nil, http.ErrNotSupported
+    }
+    return h.Hijack()
+}
+
+// Gzipper compresses the response body when the client advertises support
+// for it via Accept-Encoding.
+func Gzipper(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+            next.ServeHTTP(w, r)
+            return
+        }
+
+        w.Header().Set("Content-Encoding", "gzip")
+        w.Header().Del("Content-Length")
+
+        gz := gzip.NewWriter(w)
+        defer gz.Close()
+        next.ServeHTTP(&gzipResponseWriter{ResponseWriter: w, Writer: gz}, r)
+    })
+}
+
+// gzipResponseWriter redirects writes through a gzip.Writer while
+// preserving the underlying ResponseWriter's Flusher/Hijacker behavior.
+type gzipResponseWriter struct {
+    http.ResponseWriter
+    Writer *gzip.Writer
+}
+
+func (gw *gzipResponseWriter) Write(b []byte) (int, error) {
+    return gw.Writer.Write(b)
+}
+
+func (gw *gzipResponseWriter) Flush() {
+    gw.Writer.Flush()
+    if f, ok := gw.ResponseWriter.(http.Flusher); ok {
+        f.Flush()
+    }
+}
+
+func (gw *gzipResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+    h, ok := gw.ResponseWriter.(http.Hijacker)
+    if !ok {
+        return nil, nil, http.ErrNotSupported
+    }
+    return h.Hijack()
+}
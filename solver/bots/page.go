@@ -0,0 +1,25 @@
+package main
+
+import "os"
+
+// page is a single wiki page, persisted to disk as "<title>.txt".
+type page struct {
+    Title string
+    Body  []byte
+}
+
+// save writes p to disk, creating or truncating its backing file.
+func (p *page) save() error {
+    filename := p.Title + ".txt"
+    return os.WriteFile(filename, p.Body, 0600)
+}
+
+// loadPage reads the page named title from disk.
+func loadPage(title string) (*page, error) {
+    filename := title + ".txt"
+    body, err := os.ReadFile(filename)
+    if err != nil {
+        return nil, err
+    }
+    return &page{Title: title, Body: body}, nil
+}
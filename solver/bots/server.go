@@ -0,0 +1,166 @@
+package main
+
+import (
+    "context"
+    "html/template"
+    "io"
+    "log"
+    "net/http"
+    "os"
+    "os/signal"
+    "regexp"
+    "syscall"
+    "time"
+
+    "github.com/EliHunter173/corn-bot/solver/bots/middleware"
+)
+
+const (
+    defaultPort         = "8080"
+    readHeaderTimeout   = 5 * time.Second
+    writeTimeout        = 10 * time.Second
+    idleTimeout         = 60 * time.Second
+    shutdownGracePeriod = 10 * time.Second
+)
+
+// validPath matches the wiki routes this server accepts, capturing the
+// action and the page title so handlers can reject malformed requests
+// before touching disk.
+var validPath = regexp.MustCompile(`^/(view|edit|save)/([a-zA-Z0-9]+)$`)
+
+// server owns the bot's HTTP routes and handler state.
+type server struct {
+    mux       *http.ServeMux
+    templates *template.Template
+}
+
+func newServer() *server {
+    s := &server{
+        mux:       http.NewServeMux(),
+        templates: template.Must(template.ParseGlob("templates/*.html")),
+    }
+    s.routes()
+    return s
+}
+
+func (s *server) routes() {
+    s.mux.HandleFunc("/", s.handleHello())
+    s.mux.HandleFunc("/view/", s.handleView())
+    s.mux.HandleFunc("/edit/", s.handleEdit())
+    s.mux.HandleFunc("/save/", s.handleSave())
+    s.mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("static"))))
+}
+
+func (s *server) handleHello() http.HandlerFunc {
+    return func(w http.ResponseWriter, _ *http.Request) {
+        io.WriteString(w, "Hello from a HandleFunc!\n")
+    }
+}
+
+// titleFromPath validates r's URL against validPath and extracts the page
+// title, writing a 404 and returning ok=false if the path doesn't match.
+func titleFromPath(w http.ResponseWriter, r *http.Request) (title string, ok bool) {
+    m := validPath.FindStringSubmatch(r.URL.Path)
+    if m == nil {
+        http.NotFound(w, r)
+        return "", false
+    }
+    return m[2], true
+}
+
+func (s *server) handleView() http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        title, ok := titleFromPath(w, r)
+        if !ok {
+            return
+        }
+        p, err := loadPage(title)
+        if err != nil {
+            http.Redirect(w, r, "/edit/"+title, http.StatusFound)
+            return
+        }
+        s.renderTemplate(w, "view", p)
+    }
+}
+
+func (s *server) handleEdit() http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        title, ok := titleFromPath(w, r)
+        if !ok {
+            return
+        }
+        p, err := loadPage(title)
+        if err != nil {
+            p = &page{Title: title}
+        }
+        s.renderTemplate(w, "edit", p)
+    }
+}
+
+func (s *server) handleSave() http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        title, ok := titleFromPath(w, r)
+        if !ok {
+            return
+        }
+        p := &page{Title: title, Body: []byte(r.FormValue("body"))}
+        if err := p.save(); err != nil {
+            http.Error(w, err.Error(), http.StatusInternalServerError)
+            return
+        }
+        http.Redirect(w, r, "/view/"+title, http.StatusFound)
+    }
+}
+
+func (s *server) renderTemplate(w http.ResponseWriter, name string, p *page) {
+    if err := s.templates.ExecuteTemplate(w, name+".html", p); err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+    }
+}
+
+// listenAddr returns the address to listen on, taking the port from PORT
+// when set and falling back to defaultPort otherwise.
+func listenAddr() string {
+    port := os.Getenv("PORT")
+    if port == "" {
+        port = defaultPort
+    }
+    return ":" + port
+}
+
+// run starts the HTTP server and blocks until it shuts down, either
+// because it failed or because SIGINT/SIGTERM was received.
+func run() error {
+    s := newServer()
+    handler := middleware.Chain(middleware.Logger, middleware.Gzipper)(s.mux)
+
+    httpServer := &http.Server{
+        Addr:              listenAddr(),
+        Handler:           handler,
+        ReadHeaderTimeout: readHeaderTimeout,
+        WriteTimeout:      writeTimeout,
+        IdleTimeout:       idleTimeout,
+    }
+
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+
+    errCh := make(chan error, 1)
+    go func() {
+        log.Printf("Listening on http://localhost%s", httpServer.Addr)
+        errCh <- httpServer.ListenAndServe()
+    }()
+
+    select {
+    case err := <-errCh:
+        if err != nil && err != http.ErrServerClosed {
+            return err
+        }
+        return nil
+    case <-ctx.Done():
+        log.Println("Shutting down...")
+        shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+        defer cancel()
+        return httpServer.Shutdown(shutdownCtx)
+    }
+}